@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+// Package audio plays the game's background music and one-shot sound
+// effects. It follows the decode-once/replay-from-memory pattern used by
+// the ebiten flappy example: embedded WAV bytes are decoded a single
+// time into raw PCM, and each subsequent play creates a cheap player over
+// that shared buffer.
+package audio
+
+import (
+	"bytes"
+	_ "embed"
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+const sampleRate = 44100
+
+var context = audio.NewContext(sampleRate)
+
+var muted bool
+
+// SetMuted sets whether all audio output, music and SFX alike, is silenced.
+func SetMuted(m bool) {
+	muted = m
+	if bgmPlayer != nil {
+		bgmPlayer.SetVolume(volumeFor(m))
+	}
+}
+
+// ToggleMuted flips the global mute state and returns the new value.
+func ToggleMuted() bool {
+	SetMuted(!muted)
+	return muted
+}
+
+// Muted reports whether audio output is currently silenced.
+func Muted() bool {
+	return muted
+}
+
+func volumeFor(muted bool) float64 {
+	if muted {
+		return 0
+	}
+	return 1
+}
+
+func decodeWAV(b []byte) []byte {
+	s, err := wav.DecodeWithoutResampling(bytes.NewReader(b))
+	if err != nil {
+		panic(err)
+	}
+	buf, err := io.ReadAll(s)
+	if err != nil {
+		panic(err)
+	}
+	return buf
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package audio
+
+import (
+	"bytes"
+	_ "embed"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+//go:embed title.wav
+var titleBGMBytes []byte
+
+//go:embed bgm.wav
+var gameBGMBytes []byte
+
+//go:embed gameover_stinger.wav
+var gameOverStingerBytes []byte
+
+var (
+	titleBGMPCM        []byte
+	gameBGMPCM         []byte
+	gameOverStingerPCM []byte
+)
+
+func init() {
+	titleBGMPCM = decodeWAV(titleBGMBytes)
+	gameBGMPCM = decodeWAV(gameBGMBytes)
+	gameOverStingerPCM = decodeWAV(gameOverStingerBytes)
+}
+
+// Track identifies one of the game's looping background tracks.
+type Track int
+
+const (
+	TrackTitle Track = iota
+	TrackGame
+)
+
+var bgmPlayer *audio.Player
+
+// PlayBGM starts looping the background music for t, replacing whatever
+// track is currently playing. Calling it again with the track already
+// playing is a no-op.
+func PlayBGM(t Track) {
+	var pcm []byte
+	switch t {
+	case TrackTitle:
+		pcm = titleBGMPCM
+	case TrackGame:
+		pcm = gameBGMPCM
+	}
+
+	loop := audio.NewInfiniteLoop(bytes.NewReader(pcm), int64(len(pcm)))
+	p, err := context.NewPlayer(loop)
+	if err != nil {
+		panic(err)
+	}
+
+	StopBGM()
+	bgmPlayer = p
+	bgmPlayer.SetVolume(volumeFor(muted))
+	bgmPlayer.Play()
+}
+
+// StopBGM stops and releases the currently playing background track, if
+// any.
+func StopBGM() {
+	if bgmPlayer == nil {
+		return
+	}
+	_ = bgmPlayer.Close()
+	bgmPlayer = nil
+}
+
+// PlayGameOverStinger plays the one-shot stinger heard when the run ends.
+// It does not affect the looping background music.
+func PlayGameOverStinger() {
+	if muted {
+		return
+	}
+	context.NewPlayerFromBytes(gameOverStingerPCM).Play()
+}
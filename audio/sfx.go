@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package audio
+
+import _ "embed"
+
+//go:embed spawn.wav
+var spawnBytes []byte
+
+//go:embed resolve.wav
+var resolveBytes []byte
+
+//go:embed recovery.wav
+var recoveryBytes []byte
+
+//go:embed damage.wav
+var damageBytes []byte
+
+//go:embed hover.wav
+var hoverBytes []byte
+
+// SFX identifies one of the game's one-shot sound effects.
+type SFX int
+
+const (
+	SFXSpawn SFX = iota
+	SFXResolve
+	SFXRecovery
+	SFXDamage
+	SFXHover
+)
+
+var sfxPCM map[SFX][]byte
+
+func init() {
+	sfxPCM = map[SFX][]byte{
+		SFXSpawn:    decodeWAV(spawnBytes),
+		SFXResolve:  decodeWAV(resolveBytes),
+		SFXRecovery: decodeWAV(recoveryBytes),
+		SFXDamage:   decodeWAV(damageBytes),
+		SFXHover:    decodeWAV(hoverBytes),
+	}
+}
+
+// Play plays s once. Overlapping calls for the same effect are allowed:
+// each gets its own player over the shared decoded PCM buffer.
+func (s SFX) Play() {
+	if muted {
+		return
+	}
+	context.NewPlayerFromBytes(sfxPCM[s]).Play()
+}
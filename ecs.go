@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/hajimehoshi/ebitenginegamejam2023/audio"
+	"github.com/hajimehoshi/ebitenginegamejam2023/highscores"
+)
+
+// System is one slice of per-tick game logic or rendering, operating on
+// the shared World. Splitting updateGame's spawning/lifetime/input/HUD
+// concerns into separate systems keeps each one small and lets new
+// mechanics be added as new systems instead of growing a single method.
+type System interface {
+	Update(w *World) error
+	Draw(w *World, screen *ebiten.Image)
+}
+
+// Game dispatches to the systems registered for the current phase. It
+// holds no gameplay state itself; that lives in World.
+type Game struct {
+	world World
+
+	updateSystems map[Phase][]System
+	drawSystems   map[Phase][]System
+
+	crt *crtEffect
+}
+
+// NewGame constructs a Game with all systems registered and the world
+// ready to show the title screen.
+func NewGame() *Game {
+	phaseSystem := &PhaseSystem{}
+	inputSystem := &InputSystem{}
+	spawnSystem := &SpawnSystem{}
+	itemLifetimeSystem := &ItemLifetimeSystem{}
+	renderSystem := &RenderSystem{}
+	hudSystem := &HUDSystem{}
+	flashOverlaySystem := &FlashOverlaySystem{}
+	nameEntrySystem := &NameEntrySystem{}
+
+	g := &Game{}
+	g.world.Phase = PhaseTitle
+
+	table, err := highscores.Load()
+	if err != nil {
+		table = &highscores.Table{}
+	}
+	g.world.HighScores = table
+
+	g.updateSystems = map[Phase][]System{
+		PhaseTitle:      {phaseSystem},
+		PhaseGame:       {flashOverlaySystem, inputSystem, spawnSystem, itemLifetimeSystem},
+		PhaseGameOver:   {flashOverlaySystem, phaseSystem},
+		PhaseEnterName:  {nameEntrySystem},
+		PhaseHighScores: {phaseSystem},
+	}
+	g.drawSystems = map[Phase][]System{
+		PhaseTitle:      {phaseSystem},
+		PhaseGame:       {hudSystem, renderSystem, flashOverlaySystem},
+		PhaseGameOver:   {hudSystem, renderSystem, flashOverlaySystem, phaseSystem},
+		PhaseEnterName:  {nameEntrySystem},
+		PhaseHighScores: {phaseSystem},
+	}
+
+	if *crtFlag {
+		g.crt = newCRTEffect()
+	}
+
+	audio.PlayBGM(audio.TrackTitle)
+
+	return g
+}
+
+func (g *Game) Update() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		audio.ToggleMuted()
+	}
+
+	for _, s := range g.updateSystems[g.world.Phase] {
+		if err := s.Update(&g.world); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	if g.crt != nil {
+		g.crt.Draw(screen, g.drawPhase)
+		return
+	}
+	g.drawPhase(screen)
+}
+
+func (g *Game) drawPhase(screen *ebiten.Image) {
+	for _, s := range g.drawSystems[g.world.Phase] {
+		s.Draw(&g.world, screen)
+	}
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+// Package highscores persists the game's top scores: to a JSON file
+// under os.UserConfigDir on native builds, and to localStorage on wasm.
+package highscores
+
+import (
+	"sort"
+	"time"
+)
+
+// MaxEntries is how many scores the table keeps.
+const MaxEntries = 10
+
+// Entry is one row of the high score table. Timestamp and PlayTime are
+// carried along even though the current high score screen doesn't show
+// them, so a future stats screen can read them from the same file.
+type Entry struct {
+	Name      string        `json:"name"`
+	Score     int           `json:"score"`
+	Timestamp time.Time     `json:"timestamp"`
+	PlayTime  time.Duration `json:"playTime"`
+}
+
+// Table is the persisted set of high scores, always kept sorted by
+// descending score and capped at MaxEntries.
+type Table struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Load reads the persisted table. If none exists yet, it returns an
+// empty table and a nil error.
+func Load() (*Table, error) {
+	return load()
+}
+
+// Save persists the table.
+func (t *Table) Save() error {
+	return t.save()
+}
+
+// Qualifies reports whether score would earn a place in the table.
+func (t *Table) Qualifies(score int) bool {
+	if len(t.Entries) < MaxEntries {
+		return true
+	}
+	return score > t.Entries[len(t.Entries)-1].Score
+}
+
+// Add inserts e into the table, keeping it sorted by descending score
+// and capped at MaxEntries.
+func (t *Table) Add(e Entry) {
+	t.Entries = append(t.Entries, e)
+	sort.SliceStable(t.Entries, func(i, j int) bool {
+		return t.Entries[i].Score > t.Entries[j].Score
+	})
+	if len(t.Entries) > MaxEntries {
+		t.Entries = t.Entries[:MaxEntries]
+	}
+}
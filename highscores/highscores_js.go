@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+//go:build js
+
+package highscores
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+const localStorageKey = "ebitenginegamejam2023-highscores"
+
+func load() (*Table, error) {
+	item := js.Global().Get("localStorage").Call("getItem", localStorageKey)
+	if item.IsNull() {
+		return &Table{}, nil
+	}
+	var t Table
+	if err := json.Unmarshal([]byte(item.String()), &t); err != nil {
+		return &Table{}, err
+	}
+	return &t, nil
+}
+
+func (t *Table) save() error {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	js.Global().Get("localStorage").Call("setItem", localStorageKey, string(b))
+	return nil
+}
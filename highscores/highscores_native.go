@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+//go:build !js
+
+package highscores
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ebitenginegamejam2023", "highscores.json"), nil
+}
+
+func load() (*Table, error) {
+	path, err := configPath()
+	if err != nil {
+		return &Table{}, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Table{}, nil
+		}
+		return &Table{}, err
+	}
+	var t Table
+	if err := json.Unmarshal(b, &t); err != nil {
+		return &Table{}, err
+	}
+	return &t, nil
+}
+
+func (t *Table) save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
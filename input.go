@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// gamepadCursorSpeed is how many pixels the gamepad virtual cursor moves
+// per tick at full stick deflection.
+const gamepadCursorSpeed = 24
+
+// gamepadDeadZone is the minimum stick magnitude that counts as input,
+// to avoid drift from imprecise analog sticks.
+const gamepadDeadZone = 0.2
+
+// InputState is the unified pointer reported by whichever device was
+// used most recently: mouse, touch, keyboard grid navigation, or a
+// gamepad's virtual cursor. Item hover/press logic reads only this, so
+// it doesn't need to know which device produced it.
+type InputState struct {
+	CursorX     int
+	CursorY     int
+	JustPressed bool
+}
+
+// PointerDevice identifies which input device last positioned the
+// unified cursor, so updateInput can keep reporting that device's
+// position on ticks where it doesn't move (e.g. a gamepad stick held
+// at neutral, or no arrow key pressed this tick).
+type PointerDevice int
+
+const (
+	PointerDeviceMouse PointerDevice = iota
+	PointerDeviceGrid
+	PointerDeviceGamepad
+)
+
+// updateInput advances keyboard grid navigation and gamepad virtual
+// cursor state for one tick and returns the resulting InputState. The
+// reported cursor position always comes from whichever device was last
+// active, not only one that moved this exact tick, so the keyboard- or
+// gamepad-selected cell stays hovered once the player stops pressing a
+// direction.
+func (w *World) updateInput() InputState {
+	var state InputState
+
+	if x, y := ebiten.CursorPosition(); x != w.LastMouseX || y != w.LastMouseY {
+		w.LastMouseX, w.LastMouseY = x, y
+		w.PointerDevice = PointerDeviceMouse
+	}
+
+	touchIDs := inpututil.AppendJustPressedTouchIDs(nil)
+	for _, id := range touchIDs {
+		w.LastMouseX, w.LastMouseY = ebiten.TouchPosition(id)
+		w.PointerDevice = PointerDeviceMouse
+		state.JustPressed = true
+	}
+
+	if moved := w.moveGridSelection(); moved {
+		w.PointerDevice = PointerDeviceGrid
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		state.JustPressed = true
+	}
+
+	if w.moveGamepadCursor() {
+		w.PointerDevice = PointerDeviceGamepad
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		if inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonRightBottom) {
+			state.JustPressed = true
+		}
+	}
+
+	switch w.PointerDevice {
+	case PointerDeviceGrid:
+		state.CursorX, state.CursorY = gridCellCenter(w.SelRow, w.SelCol)
+	case PointerDeviceGamepad:
+		state.CursorX, state.CursorY = int(w.GamepadCursorX), int(w.GamepadCursorY)
+	default:
+		state.CursorX, state.CursorY = w.LastMouseX, w.LastMouseY
+	}
+
+	return state
+}
+
+// moveGridSelection handles arrow/WASD navigation between the item grid
+// cells and reports whether the selection moved this tick.
+func (w *World) moveGridSelection() bool {
+	moved := false
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft), inpututil.IsKeyJustPressed(ebiten.KeyA):
+		w.SelCol--
+		moved = true
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight), inpututil.IsKeyJustPressed(ebiten.KeyD):
+		w.SelCol++
+		moved = true
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp), inpututil.IsKeyJustPressed(ebiten.KeyW):
+		w.SelRow--
+		moved = true
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown), inpututil.IsKeyJustPressed(ebiten.KeyS):
+		w.SelRow++
+		moved = true
+	}
+	if !moved {
+		return false
+	}
+	w.SelRow = clampInt(w.SelRow, 0, gridRows-1)
+	w.SelCol = clampInt(w.SelCol, 0, gridCols-1)
+	return true
+}
+
+// moveGamepadCursor moves the gamepad virtual cursor with the left
+// stick or d-pad and reports whether any connected standard gamepad
+// produced movement this tick.
+func (w *World) moveGamepadCursor() bool {
+	moved := false
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+
+		dx := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+		dy := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical)
+		if abs(dx) < gamepadDeadZone {
+			dx = 0
+		}
+		if abs(dy) < gamepadDeadZone {
+			dy = 0
+		}
+		if ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonLeftLeft) {
+			dx = -1
+		}
+		if ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonLeftRight) {
+			dx = 1
+		}
+		if ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonLeftTop) {
+			dy = -1
+		}
+		if ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonLeftBottom) {
+			dy = 1
+		}
+		if dx == 0 && dy == 0 {
+			continue
+		}
+
+		w.GamepadCursorX = clampFloat(w.GamepadCursorX+dx*gamepadCursorSpeed, 0, screenWidth)
+		w.GamepadCursorY = clampFloat(w.GamepadCursorY+dy*gamepadCursorSpeed, 0, screenHeight)
+		moved = true
+	}
+	return moved
+}
+
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func clampFloat(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
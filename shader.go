@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import (
+	_ "embed"
+	"flag"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var crtFlag = flag.Bool("crt", false, "enable a CRT post-processing effect")
+
+//go:embed shaders/crt.kage
+var crtShaderSrc []byte
+
+// crtEffect renders the game to an offscreen image and composites it to
+// the real screen through the CRT Kage shader, following the pattern
+// used by the ebiten flappy example's -crt flag.
+type crtEffect struct {
+	shader    *ebiten.Shader
+	offscreen *ebiten.Image
+}
+
+func newCRTEffect() *crtEffect {
+	shader, err := ebiten.NewShader(crtShaderSrc)
+	if err != nil {
+		panic(err)
+	}
+	return &crtEffect{shader: shader}
+}
+
+// Draw calls render with a fresh offscreen image of screen's size, then
+// composites that offscreen image onto screen through the shader. The
+// offscreen image is reused across frames and only recreated if
+// screen's size changes.
+func (c *crtEffect) Draw(screen *ebiten.Image, render func(*ebiten.Image)) {
+	w, h := screen.Bounds().Dx(), screen.Bounds().Dy()
+	if c.offscreen == nil || c.offscreen.Bounds().Dx() != w || c.offscreen.Bounds().Dy() != h {
+		c.offscreen = ebiten.NewImage(w, h)
+	}
+	c.offscreen.Clear()
+	render(c.offscreen)
+
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = c.offscreen
+	op.Uniforms = map[string]any{
+		"ScreenSize": []float32{float32(w), float32(h)},
+	}
+	screen.DrawRectShader(w, h, c.shader, op)
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"golang.org/x/image/font"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// FlashOverlaySystem counts down the recovery/damage flash timers and
+// the wave-transition banner timer, tinting the whole screen green or
+// red while a flash is active and fading in a "WAVE N — <name>" banner
+// on a wave transition.
+type FlashOverlaySystem struct{}
+
+func (s *FlashOverlaySystem) Update(w *World) error {
+	if w.RecoveryTime > 0 {
+		w.RecoveryTime--
+	}
+	if w.DamageTime > 0 {
+		w.DamageTime--
+	}
+	if w.WaveBannerTime > 0 {
+		w.WaveBannerTime--
+	}
+	return nil
+}
+
+func (s *FlashOverlaySystem) Draw(w *World, screen *ebiten.Image) {
+	if w.RecoveryTime > 0 {
+		a := byte(0xff * float64(w.RecoveryTime) / maxRecoveryTime / 2)
+		clr := color.RGBA{0, a, 0, a}
+		vector.DrawFilledRect(screen, 0, 0, screenWidth, screenHeight, clr, false)
+	} else if w.DamageTime > 0 {
+		a := byte(0xff * float64(w.DamageTime) / maxDamageTime / 2)
+		clr := color.RGBA{a, 0, 0, a}
+		vector.DrawFilledRect(screen, 0, 0, screenWidth, screenHeight, clr, false)
+	}
+
+	if w.WaveBannerTime > 0 {
+		wave := waves[w.WaveIndex]
+		banner := fmt.Sprintf("WAVE %d — %s", w.WaveIndex+1, wave.Name)
+
+		a := font.MeasureString(titleFace, banner)
+		m := titleFace.Metrics()
+		x := (screenWidth - fixedToFloat64(a)) / 2
+		y := (screenHeight-fixedToFloat64(m.Height))/2 + fixedToFloat64(m.Ascent)
+
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x, y)
+		alpha := float32(w.WaveBannerTime) / maxWaveBannerTime
+		op.ColorScale.ScaleAlpha(alpha)
+		text.DrawWithOptions(screen, banner, titleFace, op)
+	}
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// HUDSystem draws the score and life counters.
+type HUDSystem struct{}
+
+func (s *HUDSystem) Update(w *World) error {
+	return nil
+}
+
+func (s *HUDSystem) Draw(w *World, screen *ebiten.Image) {
+	// Draw the score.
+	{
+		txt := fmt.Sprintf("GitHub Stars: %d", w.Score)
+
+		x := 32.0
+		y := 32 + fixedToFloat64(uiFace.Metrics().Ascent)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x, y)
+		text.DrawWithOptions(screen, txt, uiFace, op)
+	}
+
+	// Draw the life.
+	{
+		txt := fmt.Sprintf("Life: %d", w.PlayerLife)
+		a := font.MeasureString(uiFace, txt)
+		x := screenWidth - 32.0 - fixedToFloat64(a)
+		y := 32 + fixedToFloat64(uiFace.Metrics().Ascent)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x, y)
+		text.DrawWithOptions(screen, txt, uiFace, op)
+	}
+}
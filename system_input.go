@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// InputSystem populates World.Input once per tick from whichever device
+// produced input: mouse, touch, keyboard grid navigation, or a
+// gamepad's virtual cursor.
+type InputSystem struct{}
+
+func (s *InputSystem) Update(w *World) error {
+	w.Input = w.updateInput()
+	return nil
+}
+
+func (s *InputSystem) Draw(w *World, screen *ebiten.Image) {
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/hajimehoshi/ebitenginegamejam2023/audio"
+)
+
+// ItemLifetimeSystem advances every item on the grid, resolves the ones
+// the player clicked, and applies recovery/damage consequences for the
+// ones that expire. It transitions the world to PhaseGameOver once the
+// player runs out of life.
+type ItemLifetimeSystem struct{}
+
+func (s *ItemLifetimeSystem) Update(w *World) error {
+	w.PlayTicks++
+
+	for j := range w.Items {
+		for i := range w.Items[j] {
+			it := w.Items[j][i]
+			if it == nil {
+				continue
+			}
+			it.Update(w.Input)
+			if it.Resolved() {
+				w.Score += it.Score()
+				if it.recovery {
+					w.PlayerLife++
+					w.RecoveryTime = maxRecoveryTime
+					audio.SFXRecovery.Play()
+				} else {
+					audio.SFXResolve.Play()
+				}
+				w.Items[j][i] = nil
+			} else if it.Alive() {
+				if it.hovered {
+					ebiten.SetCursorShape(ebiten.CursorShapePointer)
+				} else {
+					ebiten.SetCursorShape(ebiten.CursorShapeDefault)
+				}
+			} else {
+				w.Items[j][i] = nil
+				w.DamageTime = maxDamageTime
+				if it.doubleDamage {
+					w.PlayerLife -= 2
+				} else {
+					w.PlayerLife--
+				}
+				audio.SFXDamage.Play()
+				if w.PlayerLife <= 0 {
+					w.Phase = PhaseGameOver
+					w.IsNewHighScore = w.HighScores.Qualifies(w.Score)
+					audio.StopBGM()
+					audio.PlayGameOverStinger()
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *ItemLifetimeSystem) Draw(w *World, screen *ebiten.Image) {
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import (
+	"image/color"
+
+	"golang.org/x/image/font"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+
+	"github.com/hajimehoshi/ebitenginegamejam2023/audio"
+)
+
+// nameEntryAlphabet is the set of letters selectable on PhaseEnterName,
+// in display order.
+const nameEntryAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// nameEntryLength is how many letters a high score name holds.
+const nameEntryLength = 3
+
+// NameEntrySystem lets the player pick a short name for a new high
+// score. Keyboard players type letters directly; gamepad and touch
+// players instead move a cursor across nameEntryAlphabet and confirm
+// one letter at a time, since neither device has a keyboard to type
+// with.
+type NameEntrySystem struct{}
+
+func (s *NameEntrySystem) Update(w *World) error {
+	for _, k := range inpututil.AppendJustPressedKeys(nil) {
+		if k < ebiten.KeyA || k > ebiten.KeyZ || len(w.EnteredName) >= nameEntryLength {
+			continue
+		}
+		w.EnteredName += string(rune('A' + (k - ebiten.KeyA)))
+		audio.SFXHover.Play()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(w.EnteredName) > 0 {
+		w.EnteredName = w.EnteredName[:len(w.EnteredName)-1]
+		audio.SFXHover.Play()
+	}
+
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		if inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonLeftLeft) {
+			w.NameCursor--
+		}
+		if inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonLeftRight) {
+			w.NameCursor++
+		}
+	}
+	n := len(nameEntryAlphabet)
+	w.NameCursor = ((w.NameCursor % n) + n) % n
+
+	confirm := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || len(inpututil.AppendJustPressedTouchIDs(nil)) > 0
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		if inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonRightBottom) {
+			confirm = true
+		}
+	}
+	if confirm && len(w.EnteredName) < nameEntryLength {
+		w.EnteredName += string(nameEntryAlphabet[w.NameCursor])
+		audio.SFXHover.Play()
+	}
+
+	if len(w.EnteredName) == nameEntryLength {
+		w.commitHighScore()
+		w.Phase = PhaseHighScores
+	}
+	return nil
+}
+
+func (s *NameEntrySystem) Draw(w *World, screen *ebiten.Image) {
+	title := "NEW HIGH SCORE"
+	a := font.MeasureString(titleFace, title)
+	x := (screenWidth - fixedToFloat64(a)) / 2
+	m := titleFace.Metrics()
+	y := screenHeight/2 - fixedToFloat64(m.Height)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x, y)
+	text.DrawWithOptions(screen, title, titleFace, op)
+
+	name := w.EnteredName
+	for len(name) < nameEntryLength {
+		name += "_"
+	}
+	a = font.MeasureString(titleFace, name)
+	x = (screenWidth - fixedToFloat64(a)) / 2
+	y = screenHeight / 2
+	op = &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x, y)
+	text.DrawWithOptions(screen, name, titleFace, op)
+
+	letter := string(nameEntryAlphabet[w.NameCursor])
+	a = font.MeasureString(uiFace, letter)
+	x = (screenWidth - fixedToFloat64(a)) / 2
+	y = screenHeight/2 + fixedToFloat64(m.Height)
+	op = &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x, y)
+	op.ColorScale.ScaleWithColor(color.RGBA{0xff, 0xff, 0, 0xff})
+	text.DrawWithOptions(screen, letter, uiFace, op)
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/hajimehoshi/ebitenginegamejam2023/audio"
+	"github.com/hajimehoshi/ebitenginegamejam2023/highscores"
+)
+
+// PhaseSystem drives the title, game-over, and high-score-list screens:
+// it starts a run from the title screen, routes game over into name
+// entry when the score qualifies, and draws all three screens' text.
+type PhaseSystem struct{}
+
+func (s *PhaseSystem) Update(w *World) error {
+	switch w.Phase {
+	case PhaseTitle:
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyH):
+			w.Phase = PhaseHighScores
+		case justPressed():
+			w.reset()
+			w.Phase = PhaseGame
+			audio.PlayBGM(audio.TrackGame)
+		}
+	case PhaseGameOver:
+		if justPressed() {
+			if w.IsNewHighScore {
+				w.Phase = PhaseEnterName
+			} else {
+				w.Phase = PhaseTitle
+				audio.PlayBGM(audio.TrackTitle)
+			}
+		}
+	case PhaseHighScores:
+		if justPressed() {
+			w.Phase = PhaseTitle
+			audio.PlayBGM(audio.TrackTitle)
+		}
+	}
+	return nil
+}
+
+func (s *PhaseSystem) Draw(w *World, screen *ebiten.Image) {
+	switch w.Phase {
+	case PhaseTitle:
+		drawTitle(screen)
+	case PhaseGameOver:
+		drawGameOver(screen, w.Score)
+	case PhaseHighScores:
+		drawHighScores(screen, w.HighScores)
+	}
+}
+
+func drawTitle(screen *ebiten.Image) {
+	lines := []string{"GAME", "ENGINE", "DEVELOPMENT", "SIMULATOR"}
+	m := titleFace.Metrics()
+	for i, line := range lines {
+		a := font.MeasureString(titleFace, line)
+		x := (screenWidth - fixedToFloat64(a)) / 2
+		y := (screenHeight - fixedToFloat64(m.Height)*float64(len(lines))) / 2
+		y += fixedToFloat64(m.Height*fixed.Int26_6(i) + m.Ascent)
+
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x, y)
+		text.DrawWithOptions(screen, line, titleFace, op)
+	}
+
+	hint := "Press H for High Scores"
+	a := font.MeasureString(uiFace, hint)
+	x := (screenWidth - fixedToFloat64(a)) / 2
+	y := screenHeight - 64.0
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x, y)
+	text.DrawWithOptions(screen, hint, uiFace, op)
+}
+
+func drawGameOver(screen *ebiten.Image, score int) {
+	clr := color.RGBA{0, 0, 0, 0x80}
+	vector.DrawFilledRect(screen, 0, 0, screenWidth, screenHeight, clr, false)
+
+	stars := fmt.Sprintf("%d STARS", score)
+	if score == 1 {
+		stars = stars[:len(stars)-1] // Remove 's' for plural.
+	}
+	lines := []string{"GAME OVER", "YOU GOT", stars}
+	m := titleFace.Metrics()
+	for i, line := range lines {
+		a := font.MeasureString(titleFace, line)
+		x := (screenWidth - fixedToFloat64(a)) / 2
+		y := (screenHeight - fixedToFloat64(m.Height)*float64(len(lines))) / 2
+		y += fixedToFloat64(m.Height*fixed.Int26_6(i) + m.Ascent)
+
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x, y)
+		text.DrawWithOptions(screen, line, titleFace, op)
+	}
+}
+
+func drawHighScores(screen *ebiten.Image, table *highscores.Table) {
+	title := "HIGH SCORES"
+	a := font.MeasureString(titleFace, title)
+	m := titleFace.Metrics()
+	x := (screenWidth - fixedToFloat64(a)) / 2
+	y := fixedToFloat64(m.Ascent) + 32
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x, y)
+	text.DrawWithOptions(screen, title, titleFace, op)
+
+	um := uiFace.Metrics()
+	rowHeight := fixedToFloat64(um.Height) * 1.5
+	listTop := y + fixedToFloat64(m.Height)
+
+	for i, e := range table.Entries {
+		row := fmt.Sprintf("%2d. %-3s %d", i+1, e.Name, e.Score)
+		rowY := listTop + rowHeight*float64(i) + fixedToFloat64(um.Ascent)
+
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(screenWidth/2-400, rowY)
+		text.DrawWithOptions(screen, row, uiFace, op)
+	}
+}
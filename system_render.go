@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// RenderSystem draws every live item on the grid.
+type RenderSystem struct{}
+
+func (s *RenderSystem) Update(w *World) error {
+	return nil
+}
+
+func (s *RenderSystem) Draw(w *World, screen *ebiten.Image) {
+	for j := range w.Items {
+		for i := range w.Items[j] {
+			it := w.Items[j][i]
+			if it == nil {
+				continue
+			}
+			it.Draw(screen)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/hajimehoshi/ebitenginegamejam2023/audio"
+)
+
+// SpawnSystem counts down the spawn cooldown and, once it elapses,
+// drops a new bug/feature-request or recovery item into a random empty
+// grid cell, using the wave active at the current score to pick its
+// cooldown, lifetime, score, and modifier. It also detects when the
+// score crosses into a new wave and starts the wave-transition banner.
+type SpawnSystem struct{}
+
+func (s *SpawnSystem) Update(w *World) error {
+	if idx := waveIndexForScore(w.Score); idx != w.WaveIndex {
+		w.WaveIndex = idx
+		w.WaveBannerTime = maxWaveBannerTime
+	}
+	wave := waves[w.WaveIndex]
+
+	if w.CoolTime > 0 {
+		w.CoolTime--
+	}
+
+	if w.CoolTime > 0 {
+		return nil
+	}
+
+	j := rand.Intn(gridRows)
+	i := rand.Intn(gridCols)
+	if w.Items[j][i] != nil {
+		return nil
+	}
+
+	x, y := gridCellCenter(j, i)
+
+	var recovery bool
+	if w.Score >= 100 {
+		chance := wave.RecoveryChance
+		switch {
+		case w.PlayerLife < 2:
+			chance *= 3
+		case w.PlayerLife < 4:
+			chance *= 1.5
+		case w.PlayerLife < maxPlayerLife:
+			chance *= 0.5
+		}
+		recovery = rand.Float64() < chance
+	}
+	if recovery {
+		var label string
+		switch rand.Intn(2) {
+		case 0:
+			label = "CONTRI-\nBUTION"
+		case 1:
+			label = "SPONSORING"
+		}
+
+		w.Items[j][i] = NewItem(label, x, y, true, 400, 0, nil)
+	} else {
+		w.BugID++
+		id := w.BugID
+		var label string
+		switch rand.Intn(2) {
+		case 0:
+			label = fmt.Sprintf("BUG\n#%d", id)
+		case 1:
+			label = fmt.Sprintf("FEATURE\nREQUEST\n#%d", id)
+		}
+
+		lifetime := wave.LifetimeRange[0] + rand.Intn(wave.LifetimeRange[1]-wave.LifetimeRange[0]+1)
+
+		baseScore := max(10, math.Sqrt(float64(w.Score))) * wave.ScoreMultiplier
+
+		w.Items[j][i] = NewItem(label, x, y, false, lifetime, int(baseScore), wave.Modifier)
+	}
+	audio.SFXSpawn.Play()
+
+	coolRange := wave.SpawnCoolRange[1] - wave.SpawnCoolRange[0]
+	w.CoolTime = wave.SpawnCoolRange[0] + rand.Intn(coolRange+1)
+
+	return nil
+}
+
+func (s *SpawnSystem) Draw(w *World, screen *ebiten.Image) {
+}
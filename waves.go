@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import "math"
+
+// Wave describes the difficulty and flavor of one stretch of the game,
+// keyed by the score at which it becomes active. Waves replace the old
+// single math.Log curve so that difficulty can jump and each stretch
+// can carry its own named "release cycle" modifier.
+type Wave struct {
+	Name string
+
+	MinScore int
+
+	SpawnCoolRange [2]int
+	LifetimeRange  [2]int
+
+	ScoreMultiplier float64
+	RecoveryChance  float64
+
+	Modifier Modifier
+}
+
+// Modifier is a per-wave behavior applied to every bug/feature-request
+// item spawned during that wave, each tick. It's how a wave can make
+// items drift, shrink, flash as critical, or punish a miss harder
+// without SpawnSystem or Item needing to know about every variant.
+type Modifier interface {
+	Apply(it *Item)
+}
+
+// waves are checked in order; the active one is the last whose
+// MinScore is at or below the current score.
+var waves = []Wave{
+	{
+		Name:            "v0.1 alpha",
+		MinScore:        0,
+		SpawnCoolRange:  [2]int{60, 120},
+		LifetimeRange:   [2]int{300, 400},
+		ScoreMultiplier: 1.0,
+		RecoveryChance:  0.1,
+	},
+	{
+		Name:            "v0.5 beta",
+		MinScore:        100,
+		SpawnCoolRange:  [2]int{45, 90},
+		LifetimeRange:   [2]int{225, 300},
+		ScoreMultiplier: 1.3,
+		RecoveryChance:  0.08,
+		Modifier:        &shrinkModifier{},
+	},
+	{
+		Name:            "v1.0 release",
+		MinScore:        1000,
+		SpawnCoolRange:  [2]int{30, 60},
+		LifetimeRange:   [2]int{150, 200},
+		ScoreMultiplier: 1.8,
+		RecoveryChance:  0.06,
+		Modifier:        &criticalBugModifier{},
+	},
+	{
+		Name:            "post-1.0 maintenance",
+		MinScore:        10000,
+		SpawnCoolRange:  [2]int{15, 30},
+		LifetimeRange:   [2]int{75, 100},
+		ScoreMultiplier: 2.5,
+		RecoveryChance:  0.05,
+		Modifier:        &securityAdvisoryModifier{},
+	},
+	{
+		Name:            "v2.0 rewrite",
+		MinScore:        100000,
+		SpawnCoolRange:  [2]int{5, 10},
+		LifetimeRange:   [2]int{40, 60},
+		ScoreMultiplier: 3.5,
+		RecoveryChance:  0.04,
+		Modifier:        &driftModifier{},
+	},
+}
+
+// waveIndexForScore returns the index into waves of the wave active at
+// the given score.
+func waveIndexForScore(score int) int {
+	idx := 0
+	for i, w := range waves {
+		if score >= w.MinScore {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// shrinkModifier makes an item's hitbox shrink as its lifetime runs
+// out, rewarding players who click it early.
+type shrinkModifier struct{}
+
+func (shrinkModifier) Apply(it *Item) {
+	it.hitboxScale = 0.4 + 0.6*float64(it.lifetime)/float64(it.initLifetime)
+}
+
+// criticalBugModifier marks an item as a "critical bug": worth 5x
+// score and flashing red to stand out.
+type criticalBugModifier struct{}
+
+func (criticalBugModifier) Apply(it *Item) {
+	it.critical = true
+	it.scoreMultiplier = 5
+}
+
+// securityAdvisoryModifier marks an item as a "security advisory":
+// missing it costs the player two lives instead of one.
+type securityAdvisoryModifier struct{}
+
+func (securityAdvisoryModifier) Apply(it *Item) {
+	it.doubleDamage = true
+}
+
+// driftModifier makes an item wander across the grid cell instead of
+// sitting still, using its own countdown as a phase so no extra state
+// is needed per item.
+type driftModifier struct{}
+
+func (driftModifier) Apply(it *Item) {
+	const amplitude = 48.0
+	phase := float64(it.initLifetime-it.lifetime) / 20
+	it.centerX = it.spawnX + int(amplitude*math.Sin(phase))
+	it.centerY = it.spawnY + int(amplitude*math.Cos(phase*0.7))
+}
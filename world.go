@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2023 Hajime Hoshi
+
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/hajimehoshi/ebitenginegamejam2023/highscores"
+)
+
+// World holds all of the gameplay state shared between systems: score,
+// life, timers, the item grid, and the current unified input.
+type World struct {
+	Phase Phase
+
+	Score        int
+	Items        [gridRows][gridCols]*Item
+	CoolTime     int
+	BugID        int
+	PlayerLife   int
+	RecoveryTime int
+	DamageTime   int
+	PlayTicks    int
+
+	WaveIndex      int
+	WaveBannerTime int
+
+	SelRow, SelCol         int
+	GamepadCursorX         float64
+	GamepadCursorY         float64
+	LastMouseX, LastMouseY int
+	PointerDevice          PointerDevice
+	Input                  InputState
+
+	HighScores     *highscores.Table
+	IsNewHighScore bool
+	EnteredName    string
+	NameCursor     int
+}
+
+// reset clears the world back to the start of a fresh run.
+func (w *World) reset() {
+	w.Score = 0
+	for j := range w.Items {
+		for i := range w.Items[j] {
+			w.Items[j][i] = nil
+		}
+	}
+	w.CoolTime = 0
+	w.BugID = 0
+	w.PlayerLife = initPlayerLife
+	w.RecoveryTime = 0
+	w.DamageTime = 0
+	w.PlayTicks = 0
+
+	w.WaveIndex = -1
+	w.WaveBannerTime = 0
+
+	w.SelRow, w.SelCol = 0, 0
+	initX, initY := gridCellCenter(0, 0)
+	w.GamepadCursorX, w.GamepadCursorY = float64(initX), float64(initY)
+	w.LastMouseX, w.LastMouseY = 0, 0
+	w.PointerDevice = PointerDeviceMouse
+
+	w.IsNewHighScore = false
+	w.EnteredName = ""
+	w.NameCursor = 0
+}
+
+// commitHighScore saves the current run's score under EnteredName and
+// persists the table. It's called once the player finishes entering
+// their name on PhaseEnterName.
+func (w *World) commitHighScore() {
+	name := w.EnteredName
+	if name == "" {
+		name = "???"
+	}
+	w.HighScores.Add(highscores.Entry{
+		Name:      name,
+		Score:     w.Score,
+		Timestamp: time.Now(),
+		PlayTime:  time.Duration(w.PlayTicks) * time.Second / time.Duration(ebiten.TPS()),
+	})
+	if err := w.HighScores.Save(); err != nil {
+		log.Printf("highscores: failed to save: %v", err)
+	}
+}